@@ -0,0 +1,120 @@
+package workerpool
+
+import (
+	"sort"
+	"sync"
+)
+
+// JobBarrier declares a shared-resource concurrency limit a job participates
+// in, e.g. {Key: "workspace:acme", MaxInFlight: 1} to serialize all jobs
+// touching a given workspace.
+type JobBarrier struct {
+	Key         string
+	MaxInFlight int
+}
+
+// JobBarriers is the set of barrier declarations for a single job.
+type JobBarriers []JobBarrier
+
+// BarrierTracker maintains per-key in-flight counts so the scheduler can gate
+// job starts on shared-resource concurrency limits, independent of the DAG's
+// dependency-based gating.
+type BarrierTracker struct {
+	mu       sync.Mutex
+	inFlight map[string]int
+}
+
+// NewBarrierTracker returns an empty tracker, ready to use.
+func NewBarrierTracker() *BarrierTracker {
+	return &BarrierTracker{inFlight: make(map[string]int)}
+}
+
+// CanStart reports whether every barrier key declared for job currently has
+// spare capacity.
+func (t *BarrierTracker) CanStart(job string, barriers map[string]JobBarriers) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, b := range barriers[job] {
+		if b.MaxInFlight <= 0 {
+			// Zero value / unset: treat as unlimited rather than deadlocking.
+			continue
+		}
+		if t.inFlight[b.Key] >= b.MaxInFlight {
+			return false
+		}
+	}
+	return true
+}
+
+// Start records job as in-flight against every one of its barrier keys.
+// Callers must have already confirmed CanStart.
+func (t *BarrierTracker) Start(job string, barriers map[string]JobBarriers) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, b := range barriers[job] {
+		if b.MaxInFlight <= 0 {
+			// Unlimited: don't hold a slot that would count against a
+			// differently-limited job sharing the same key.
+			continue
+		}
+		t.inFlight[b.Key]++
+	}
+}
+
+// Finish releases job's hold on every one of its barrier keys.
+func (t *BarrierTracker) Finish(job string, barriers map[string]JobBarriers) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, b := range barriers[job] {
+		if b.MaxInFlight <= 0 {
+			continue
+		}
+		if t.inFlight[b.Key] > 0 {
+			t.inFlight[b.Key]--
+		}
+	}
+}
+
+// BlockedByResource returns the barrier keys for job that currently lack
+// capacity, i.e. the keys causing job to wait even though it is DAG-ready.
+func (t *BarrierTracker) BlockedByResource(job string, barriers map[string]JobBarriers) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var out []string
+	for _, b := range barriers[job] {
+		if b.MaxInFlight <= 0 {
+			continue
+		}
+		if t.inFlight[b.Key] >= b.MaxInFlight {
+			out = append(out, b.Key)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// BlockedByResource is the resource-gating mirror of Blockers: it reports
+// which of job's barrier keys are currently saturated, so callers can tell a
+// job apart that is DAG-ready but waiting on a shared resource from one still
+// blocked by its dependencies.
+func (r DAGResult) BlockedByResource(job string, barriers map[string]JobBarriers, tracker *BarrierTracker) []string {
+	if tracker == nil {
+		return nil
+	}
+	return tracker.BlockedByResource(job, barriers)
+}
+
+// FrontiersWithBarriers is Frontiers augmented with barrier-key gating: each
+// returned item's BlockedByResource field is populated from tracker, so a job
+// that is ready per the DAG but waiting on a saturated barrier key is
+// distinguishable from one still blocked by its dependencies.
+func (r DAGResult) FrontiersWithBarriers(dag DAG, barriers map[string]JobBarriers, tracker *BarrierTracker) Frontiers {
+	items := r.Frontiers(dag)
+	for _, item := range items {
+		// Running and failed jobs are not waiting to start.
+		if len(item.BlockedBy) == 0 && item.Status != JobStatusInProgress && item.Status != JobStatusFailed {
+			item.BlockedByResource = r.BlockedByResource(item.Job, barriers, tracker)
+		}
+	}
+	return items
+}
@@ -0,0 +1,92 @@
+package workerpool
+
+import "testing"
+
+func TestBarrierTrackerGatesConcurrencyPerKey(t *testing.T) {
+	tracker := NewBarrierTracker()
+	barriers := map[string]JobBarriers{
+		"a": {{Key: "workspace:acme", MaxInFlight: 2}},
+		"b": {{Key: "workspace:acme", MaxInFlight: 2}},
+		"c": {{Key: "workspace:acme", MaxInFlight: 2}},
+	}
+
+	if !tracker.CanStart("a", barriers) {
+		t.Fatal("a should be able to start: key is empty")
+	}
+	tracker.Start("a", barriers)
+
+	if !tracker.CanStart("b", barriers) {
+		t.Fatal("b should be able to start: only 1 of 2 slots used")
+	}
+	tracker.Start("b", barriers)
+
+	if tracker.CanStart("c", barriers) {
+		t.Fatal("c should be blocked: both slots for workspace:acme are in use")
+	}
+
+	tracker.Finish("a", barriers)
+	if !tracker.CanStart("c", barriers) {
+		t.Fatal("c should be able to start once a frees its slot")
+	}
+}
+
+func TestBarrierTrackerMaxInFlightOneIsStrictOrdering(t *testing.T) {
+	tracker := NewBarrierTracker()
+	barriers := map[string]JobBarriers{
+		"a": {{Key: "destination:xyz", MaxInFlight: 1}},
+		"b": {{Key: "destination:xyz", MaxInFlight: 1}},
+	}
+
+	tracker.Start("a", barriers)
+	if tracker.CanStart("b", barriers) {
+		t.Fatal("b should be blocked while a holds the only slot")
+	}
+	tracker.Finish("a", barriers)
+	if !tracker.CanStart("b", barriers) {
+		t.Fatal("b should be able to start once a releases the slot")
+	}
+}
+
+func TestBarrierTrackerUnlimitedDoesNotCountAgainstLimitedPeer(t *testing.T) {
+	tracker := NewBarrierTracker()
+	barriers := map[string]JobBarriers{
+		"unlimited": {{Key: "shared", MaxInFlight: 0}},
+		"limited":   {{Key: "shared", MaxInFlight: 1}},
+	}
+
+	tracker.Start("unlimited", barriers)
+	if !tracker.CanStart("limited", barriers) {
+		t.Fatal("an unlimited job's in-flight hold must not block a differently-limited peer on the same key")
+	}
+	tracker.Start("limited", barriers)
+	tracker.Finish("unlimited", barriers)
+
+	if tracker.CanStart("limited", barriers) {
+		t.Fatal("limited job should still be blocking on its own in-flight hold after unlimited peer finishes")
+	}
+}
+
+func TestFrontiersWithBarriersDistinguishesResourceBlocked(t *testing.T) {
+	dag := DAG{
+		"a": nil,
+		"b": nil,
+	}
+	barriers := map[string]JobBarriers{
+		"a": {{Key: "k", MaxInFlight: 1}},
+		"b": {{Key: "k", MaxInFlight: 1}},
+	}
+	tracker := NewBarrierTracker()
+	tracker.Start("a", barriers)
+
+	result := newDAGResult(dag)
+	result["a"].NotReady() // "a" is the one running, holding the slot
+
+	items := result.FrontiersWithBarriers(dag, barriers, tracker).AsMap()
+
+	if len(items["b"].BlockedBy) != 0 {
+		t.Errorf("b should have no dependency blockers, got %v", items["b"].BlockedBy)
+	}
+	if len(items["b"].BlockedByResource) == 0 {
+		t.Error("b is DAG-ready but should be reported as resource-blocked on key k")
+	}
+}
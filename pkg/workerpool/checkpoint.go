@@ -0,0 +1,210 @@
+package workerpool
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// checkpointSchemaVersion is bumped whenever the on-disk shape of a
+// checkpoint changes in a way that requires migration.
+const checkpointSchemaVersion = 1
+
+// checkpoint is the serialized form of a DAGResult, versioned so future
+// changes to JobResult can detect and migrate older checkpoints.
+type checkpoint struct {
+	SchemaVersion int                   `json:"schema_version"`
+	Jobs          map[string]*JobResult `json:"jobs"`
+}
+
+// MarshalJSON implements json.Marshaler, wrapping the result in a
+// schema-versioned envelope.
+func (r DAGResult) MarshalJSON() ([]byte, error) {
+	return json.Marshal(checkpoint{
+		SchemaVersion: checkpointSchemaVersion,
+		Jobs:          map[string]*JobResult(r),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, reading a schema-versioned
+// checkpoint back into a DAGResult.
+func (r *DAGResult) UnmarshalJSON(data []byte) error {
+	var cp checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return err
+	}
+	if cp.SchemaVersion != checkpointSchemaVersion {
+		return fmt.Errorf("workerpool: unsupported checkpoint schema version %d (expected %d)", cp.SchemaVersion, checkpointSchemaVersion)
+	}
+	if cp.Jobs == nil {
+		cp.Jobs = make(map[string]*JobResult)
+	}
+	*r = DAGResult(cp.Jobs)
+	return nil
+}
+
+// jobResultJSON mirrors JobResult for serialization, replacing the Error
+// interface field (which encoding/json cannot round-trip) with a plain
+// string.
+type jobResultJSON struct {
+	Name       string
+	Status     JobStatus
+	ErrorMsg   string `json:"error,omitempty"`
+	CausedBy   []string
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Attempt    int
+}
+
+// MarshalJSON implements json.Marshaler, serializing Error as its message
+// string.
+func (j JobResult) MarshalJSON() ([]byte, error) {
+	var errMsg string
+	if j.Error != nil {
+		errMsg = j.Error.Error()
+	}
+	return json.Marshal(jobResultJSON{
+		Name:       j.Name,
+		Status:     j.Status,
+		ErrorMsg:   errMsg,
+		CausedBy:   j.CausedBy,
+		StartedAt:  j.StartedAt,
+		FinishedAt: j.FinishedAt,
+		Attempt:    j.Attempt,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, rehydrating Error from its
+// serialized message string.
+func (j *JobResult) UnmarshalJSON(data []byte) error {
+	var shadow jobResultJSON
+	if err := json.Unmarshal(data, &shadow); err != nil {
+		return err
+	}
+	j.Name = shadow.Name
+	j.Status = shadow.Status
+	j.CausedBy = shadow.CausedBy
+	j.StartedAt = shadow.StartedAt
+	j.FinishedAt = shadow.FinishedAt
+	j.Attempt = shadow.Attempt
+	j.Error = nil
+	if shadow.ErrorMsg != "" {
+		j.Error = errors.New(shadow.ErrorMsg)
+	}
+	return nil
+}
+
+// CheckpointSink persists a DAGResult after every status transition so a
+// long-running pipeline can be resumed after a crash without replaying
+// successful work.
+type CheckpointSink interface {
+	Write(DAGResult) error
+}
+
+// MemorySink is an in-memory CheckpointSink, useful in tests.
+type MemorySink struct {
+	mu   sync.Mutex
+	last DAGResult
+}
+
+// NewMemorySink returns an empty MemorySink.
+func NewMemorySink() *MemorySink {
+	return &MemorySink{}
+}
+
+func (s *MemorySink) Write(r DAGResult) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.last = r.Snapshot()
+	return nil
+}
+
+// Last returns the most recently written DAGResult, or nil if Write was never
+// called.
+func (s *MemorySink) Last() DAGResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.last
+}
+
+// FileSink is a CheckpointSink that writes to a file on disk, using a
+// write-to-temp-then-rename so a reader never observes a partially written
+// checkpoint.
+type FileSink struct {
+	Path string
+}
+
+// NewFileSink returns a FileSink writing checkpoints to path.
+func NewFileSink(path string) *FileSink {
+	return &FileSink{Path: path}
+}
+
+func (s *FileSink) Write(r DAGResult) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), filepath.Base(s.Path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+// resumeOptions configures Resume.
+type resumeOptions struct {
+	retryFailed bool
+}
+
+// ResumeOption configures Resume.
+type ResumeOption func(*resumeOptions)
+
+// RetryFailedOnResume resets jobs that were JobStatusFailed in the prior run
+// back to JobStatusUnknown, so they are retried rather than left failed.
+func RetryFailedOnResume() ResumeOption {
+	return func(o *resumeOptions) { o.retryFailed = true }
+}
+
+// Resume rebuilds a DAGResult for dag from a prior checkpoint: successful
+// jobs are preserved and will be skipped by the scheduler, in-progress jobs
+// are downgraded to unknown (their worker died with the process), and failed
+// jobs are retained as failed unless RetryFailedOnResume is passed, in which
+// case they are reset to unknown too.
+func Resume(dag DAG, prior DAGResult, opts ...ResumeOption) DAGResult {
+	var o resumeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	result := newDAGResult(dag)
+	for job, jr := range result {
+		priorJR := prior.get(job)
+
+		switch priorJR.Status {
+		case JobStatusSuccess:
+			*jr = *priorJR
+		case JobStatusFailed:
+			if o.retryFailed {
+				continue
+			}
+			*jr = *priorJR
+		case JobStatusInProgress, JobStatusSkipped, JobStatusUnknown:
+			// Fall through to the fresh JobStatusUnknown result.
+		}
+	}
+	return result
+}
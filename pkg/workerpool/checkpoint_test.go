@@ -0,0 +1,96 @@
+package workerpool
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckpointRoundTripPreservesFailedJob(t *testing.T) {
+	result := DAGResult{
+		"a": {Name: "a", Status: JobStatusSuccess},
+		"b": {Name: "b", Status: JobStatusFailed, Error: errors.New("boom")},
+	}
+
+	data, err := result.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON: %v", err)
+	}
+
+	var got DAGResult
+	if err := got.UnmarshalJSON(data); err != nil {
+		t.Fatalf("UnmarshalJSON: %v", err)
+	}
+
+	if got["a"].Status != JobStatusSuccess {
+		t.Errorf("a status = %v, want Success", got["a"].Status)
+	}
+	if got["b"].Status != JobStatusFailed {
+		t.Errorf("b status = %v, want Failed", got["b"].Status)
+	}
+	if got["b"].Error == nil || got["b"].Error.Error() != "boom" {
+		t.Errorf("b error = %v, want %q", got["b"].Error, "boom")
+	}
+}
+
+func TestCheckpointRejectsUnknownSchemaVersion(t *testing.T) {
+	var got DAGResult
+	err := got.UnmarshalJSON([]byte(`{"schema_version":99,"jobs":{}}`))
+	if err == nil {
+		t.Fatal("expected an error for an unsupported schema version, got nil")
+	}
+}
+
+func TestResumeStateTransitions(t *testing.T) {
+	dag := DAG{
+		"success":     nil,
+		"in-progress": nil,
+		"failed":      nil,
+		"unknown":     nil,
+	}
+	prior := DAGResult{
+		"success":     {Name: "success", Status: JobStatusSuccess},
+		"in-progress": {Name: "in-progress", Status: JobStatusInProgress},
+		"failed":      {Name: "failed", Status: JobStatusFailed, Error: errors.New("boom")},
+		"unknown":     {Name: "unknown", Status: JobStatusUnknown},
+	}
+
+	result := Resume(dag, prior)
+
+	if got := result["success"].Status; got != JobStatusSuccess {
+		t.Errorf("success status = %v, want Success", got)
+	}
+	if got := result["in-progress"].Status; got != JobStatusUnknown {
+		t.Errorf("in-progress status = %v, want Unknown (worker died with the process)", got)
+	}
+	if got := result["failed"].Status; got != JobStatusFailed {
+		t.Errorf("failed status = %v, want Failed (retained by default)", got)
+	}
+	if got := result["unknown"].Status; got != JobStatusUnknown {
+		t.Errorf("unknown status = %v, want Unknown", got)
+	}
+}
+
+func TestResumeRetryFailedOnResume(t *testing.T) {
+	dag := DAG{"failed": nil}
+	prior := DAGResult{"failed": {Name: "failed", Status: JobStatusFailed, Error: errors.New("boom")}}
+
+	result := Resume(dag, prior, RetryFailedOnResume())
+
+	if got := result["failed"].Status; got != JobStatusUnknown {
+		t.Errorf("failed status = %v, want Unknown when RetryFailedOnResume is set", got)
+	}
+}
+
+func TestMemorySinkStoresSnapshot(t *testing.T) {
+	sink := NewMemorySink()
+	result := DAGResult{"a": {Name: "a", Status: JobStatusSuccess}}
+
+	if err := sink.Write(result); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	result["a"].Status = JobStatusFailed
+	if got := sink.Last()["a"].Status; got != JobStatusSuccess {
+		t.Errorf("Last()[a].Status = %v, want Success (mutating the original must not affect the stored snapshot)", got)
+	}
+}
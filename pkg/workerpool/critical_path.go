@@ -0,0 +1,99 @@
+package workerpool
+
+import "time"
+
+// defaultJobDuration is used for any job without an entry in the durations
+// map passed to CriticalPath.
+const defaultJobDuration = time.Duration(1)
+
+// CriticalPath returns the ordered chain of jobs forming the current
+// critical path: starting from the not-yet-successful job with the longest
+// weighted path to a leaf, and at each step following whichever successor
+// (also not yet successful) has the longest remaining path. Ties break on
+// job name for determinism. Durations can come from prior-run history (see
+// CheckpointSink) or default to 1 per job.
+func (r DAGResult) CriticalPath(dag DAG, durations map[string]time.Duration) []string {
+	rev := r.reverseDAG(dag)
+	cp := criticalPathLengths(dag, rev, durations)
+
+	var jobs []string
+	for job := range dag {
+		jobs = append(jobs, job)
+	}
+
+	start, ok := r.longestPending(jobs, cp)
+	if !ok {
+		return nil
+	}
+
+	path := []string{start}
+	for cur := start; ; {
+		next, ok := r.longestPending(rev[cur], cp)
+		if !ok {
+			break
+		}
+		path = append(path, next)
+		cur = next
+	}
+	return path
+}
+
+// longestPending returns whichever not-yet-successful job in candidates has
+// the largest cp value, breaking ties on name.
+func (r DAGResult) longestPending(candidates []string, cp map[string]time.Duration) (string, bool) {
+	best := ""
+	var bestLen time.Duration = -1
+	for _, job := range candidates {
+		if r.isSuccess(job) {
+			continue
+		}
+		l := cp[job]
+		if l > bestLen || (l == bestLen && (best == "" || job < best)) {
+			bestLen = l
+			best = job
+		}
+	}
+	return best, best != ""
+}
+
+// criticalPathLengths computes, for every job, the longest duration-weighted
+// path from that job to any leaf in rev (the successor graph), memoized in
+// reverse-topological order: cp[n] = duration[n] + max(cp[s] for s in
+// successors[n]).
+func criticalPathLengths(dag, rev DAG, durations map[string]time.Duration) map[string]time.Duration {
+	cp := make(map[string]time.Duration, len(dag))
+	visiting := make(map[string]bool, len(dag))
+
+	var length func(string) time.Duration
+	length = func(job string) time.Duration {
+		if v, ok := cp[job]; ok {
+			return v
+		}
+		if visiting[job] {
+			// Shouldn't happen in a DAG, but avoid infinite recursion.
+			return 0
+		}
+		visiting[job] = true
+		defer delete(visiting, job)
+
+		var maxSucc time.Duration
+		for _, s := range rev[job] {
+			if l := length(s); l > maxSucc {
+				maxSucc = l
+			}
+		}
+
+		d, ok := durations[job]
+		if !ok {
+			d = defaultJobDuration
+		}
+		total := d + maxSucc
+		cp[job] = total
+		return total
+	}
+
+	for job := range dag {
+		length(job)
+	}
+	return cp
+}
@@ -0,0 +1,44 @@
+package workerpool
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCriticalPathOrdering(t *testing.T) {
+	// a -> b -> d (long chain)
+	// a -> c      (short chain)
+	dag := DAG{
+		"a": nil,
+		"b": {"a"},
+		"c": {"a"},
+		"d": {"b"},
+	}
+	durations := map[string]time.Duration{
+		"a": time.Minute,
+		"b": time.Minute,
+		"c": time.Second,
+		"d": time.Minute,
+	}
+	result := newDAGResult(dag)
+
+	path := result.CriticalPath(dag, durations)
+	if !reflect.DeepEqual(path, []string{"a", "b", "d"}) {
+		t.Fatalf("CriticalPath = %v, want [a b d] (longest remaining chain)", path)
+	}
+}
+
+func TestCriticalPathSkipsAlreadySuccessfulJobs(t *testing.T) {
+	dag := DAG{
+		"a": nil,
+		"b": {"a"},
+	}
+	result := newDAGResult(dag)
+	result["a"].Status = JobStatusSuccess
+
+	path := result.CriticalPath(dag, nil)
+	if !reflect.DeepEqual(path, []string{"b"}) {
+		t.Fatalf("CriticalPath = %v, want [b] (a is already successful)", path)
+	}
+}
@@ -0,0 +1,76 @@
+package workerpool
+
+import "sort"
+
+// RootFailures returns the failed jobs whose direct dependencies are all
+// successful, i.e. the failure originated in this job rather than being
+// inherited from an upstream one.
+func (r DAGResult) RootFailures(dag DAG) []string {
+	var out []string
+	for job, jr := range r {
+		if jr.Status != JobStatusFailed {
+			continue
+		}
+		if len(r.Blockers(dag, job)) == 0 {
+			out = append(out, job)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// CascadeSkipped maps each root failure (see RootFailures) to every job that
+// was skipped as a transitive consequence of it. A job that descends from
+// more than one root failure is listed under all of them.
+func (r DAGResult) CascadeSkipped(dag DAG) map[string][]string {
+	rev := r.reverseDAG(dag)
+	out := make(map[string][]string)
+
+	for _, root := range r.RootFailures(dag) {
+		var cascade []string
+		seen := map[string]bool{}
+
+		var dfs func(string)
+		dfs = func(n string) {
+			for _, s := range rev[n] {
+				if seen[s] {
+					continue
+				}
+				seen[s] = true
+				if r.get(s).Status == JobStatusSkipped && r.attributableTo(s, root, map[string]bool{}) {
+					cascade = append(cascade, s)
+				}
+				dfs(s)
+			}
+		}
+		dfs(root)
+
+		sort.Strings(cascade)
+		out[root] = cascade
+	}
+	return out
+}
+
+// attributableTo reports whether job's recorded CausedBy chain traces back to
+// root, walking multi-level cascades (c caused by b, b caused by a)
+// transitively rather than only checking the immediate cause. It falls back
+// to true when job's own CausedBy wasn't populated (e.g. results loaded from
+// an older checkpoint); a chain that ends at some other job is not root's.
+func (r DAGResult) attributableTo(job, root string, visited map[string]bool) bool {
+	if visited[job] {
+		return false
+	}
+	visited[job] = true
+
+	causedBy := r.get(job).CausedBy
+	if len(causedBy) == 0 {
+		// Only the job being classified (the first call) gets the fallback.
+		return len(visited) == 1
+	}
+	for _, c := range causedBy {
+		if c == root || r.attributableTo(c, root, visited) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package workerpool
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestRootFailuresAndCascadeSkippedMultiLevel(t *testing.T) {
+	// a (failed) -> b (skipped) -> c (skipped)
+	dag := DAG{
+		"a": nil,
+		"b": {"a"},
+		"c": {"b"},
+	}
+	result := DAGResult{
+		"a": {Name: "a", Status: JobStatusFailed},
+		"b": {Name: "b", Status: JobStatusSkipped, CausedBy: []string{"a"}},
+		"c": {Name: "c", Status: JobStatusSkipped, CausedBy: []string{"b"}},
+	}
+
+	roots := result.RootFailures(dag)
+	if !reflect.DeepEqual(roots, []string{"a"}) {
+		t.Fatalf("RootFailures = %v, want [a]", roots)
+	}
+
+	cascade := result.CascadeSkipped(dag)["a"]
+	sort.Strings(cascade)
+	if !reflect.DeepEqual(cascade, []string{"b", "c"}) {
+		t.Fatalf("CascadeSkipped[a] = %v, want [b c] (multi-level cascade must attribute c to a)", cascade)
+	}
+}
+
+func TestCascadeSkippedAttributesToMultipleRoots(t *testing.T) {
+	// a (failed) -\
+	//              -> c (skipped, depends on both)
+	// b (failed) -/
+	dag := DAG{
+		"a": nil,
+		"b": nil,
+		"c": {"a", "b"},
+	}
+	result := DAGResult{
+		"a": {Name: "a", Status: JobStatusFailed},
+		"b": {Name: "b", Status: JobStatusFailed},
+		"c": {Name: "c", Status: JobStatusSkipped, CausedBy: []string{"a", "b"}},
+	}
+
+	cascade := result.CascadeSkipped(dag)
+	if !reflect.DeepEqual(cascade["a"], []string{"c"}) {
+		t.Errorf("CascadeSkipped[a] = %v, want [c]", cascade["a"])
+	}
+	if !reflect.DeepEqual(cascade["b"], []string{"c"}) {
+		t.Errorf("CascadeSkipped[b] = %v, want [c]", cascade["b"])
+	}
+}
@@ -24,18 +24,23 @@ const timeoutError = "timeout_error"
 type DAGResult map[string]*JobResult
 
 type JobResult struct {
-	Name   string
-	Status JobStatus
-	Error  error
+	Name       string
+	Status     JobStatus
+	Error      error
+	CausedBy   []string // jobs whose failure led the orchestrator to skip this one
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Attempt    int
 }
 
 // FrontierItem is item in the workflow where work stay
 type FrontierItem struct {
-	Job       string
-	Status    JobStatus
-	BlockedBy []string
-	Subtree   []string // optional: successors that depend on this job
-	dagResult DAGResult
+	Job               string
+	Status            JobStatus
+	BlockedBy         []string
+	BlockedByResource []string // non-empty when the job is DAG-ready but waiting on a barrier key
+	Subtree           []string // optional: successors that depend on this job
+	dagResult         DAGResult
 }
 
 type Frontiers []*FrontierItem
@@ -75,6 +80,13 @@ func (j *JobResult) Skip() {
 	j.Status = JobStatusSkipped
 }
 
+// SkipDueTo marks the job skipped and records the failed jobs that caused the
+// skip, so attribution survives even if the DAG shape is later mutated.
+func (j *JobResult) SkipDueTo(causedBy ...string) {
+	j.Status = JobStatusSkipped
+	j.CausedBy = append(j.CausedBy, causedBy...)
+}
+
 func (j *JobResult) Success() {
 	j.Status = JobStatusSuccess
 }
@@ -97,6 +109,20 @@ func (r DAGResult) TimeoutError(deadline time.Duration) {
 
 func TimeoutErrorKey() string { return timeoutError }
 
+// Snapshot returns a deep copy of r, safe to read concurrently outside the
+// orchestrator (the map itself is not thread-safe, see the DAGResult doc).
+func (r DAGResult) Snapshot() DAGResult {
+	cp := make(DAGResult, len(r))
+	for job, jr := range r {
+		jrCopy := *jr
+		if jr.CausedBy != nil {
+			jrCopy.CausedBy = append([]string(nil), jr.CausedBy...)
+		}
+		cp[job] = &jrCopy
+	}
+	return cp
+}
+
 func (r DAGResult) FirstError() error {
 	for _, jobResult := range r {
 		if jobResult.Error != nil {
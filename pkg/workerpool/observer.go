@@ -0,0 +1,219 @@
+package workerpool
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+)
+
+// Observer receives scheduler events as the orchestrator runs a DAG.
+// Implementations must not block, and must not be called from more than one
+// goroutine at a time (ObserverHub guarantees serialized delivery per
+// observer).
+type Observer interface {
+	OnJobStart(name string)
+	OnJobFinish(result *JobResult)
+	OnFrontier(f Frontiers)
+}
+
+// observerQueueSize bounds how many pending events an observer can fall
+// behind on before new ones are dropped.
+const observerQueueSize = 64
+
+type observerEventKind int
+
+const (
+	eventJobStart observerEventKind = iota
+	eventJobFinish
+	eventFrontier
+)
+
+type observerEvent struct {
+	kind      observerEventKind
+	jobName   string
+	result    *JobResult
+	frontiers Frontiers
+}
+
+type observerHandle struct {
+	observer Observer
+	events   chan observerEvent
+}
+
+// observerCloser is implemented by observers that hold a resource (e.g. a
+// downstream channel) which must be torn down when the hub stops dispatching
+// to them. ChannelObserver implements it.
+type observerCloser interface {
+	Close()
+}
+
+// ObserverHub fans scheduler events out to any number of registered
+// Observers without blocking the scheduler goroutine: each observer gets its
+// own buffered channel and dispatch goroutine, and a slow observer has events
+// dropped (with a logged warning) rather than stalling job execution.
+type ObserverHub struct {
+	mu      sync.Mutex
+	handles []*observerHandle
+	wg      sync.WaitGroup
+}
+
+// NewObserverHub returns an empty hub, ready to Register observers.
+func NewObserverHub() *ObserverHub {
+	return &ObserverHub{}
+}
+
+// Register adds o to the hub and starts its dispatch goroutine. Call Close
+// when the hub is no longer needed to stop the goroutines it started.
+func (h *ObserverHub) Register(o Observer) {
+	handle := &observerHandle{observer: o, events: make(chan observerEvent, observerQueueSize)}
+
+	h.mu.Lock()
+	h.handles = append(h.handles, handle)
+	h.mu.Unlock()
+
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		handle.run()
+	}()
+}
+
+// Close stops every dispatch goroutine started by Register, waits for them to
+// drain, and closes any registered observer that implements observerCloser
+// (e.g. ChannelObserver, so a `range obs.Results()` consumer unblocks). The
+// hub must not be used after Close.
+func (h *ObserverHub) Close() {
+	h.mu.Lock()
+	handles := h.handles
+	h.handles = nil
+	for _, handle := range handles {
+		close(handle.events)
+	}
+	h.mu.Unlock()
+
+	h.wg.Wait()
+
+	for _, handle := range handles {
+		if closer, ok := handle.observer.(observerCloser); ok {
+			closer.Close()
+		}
+	}
+}
+
+func (handle *observerHandle) run() {
+	for ev := range handle.events {
+		switch ev.kind {
+		case eventJobStart:
+			handle.observer.OnJobStart(ev.jobName)
+		case eventJobFinish:
+			handle.observer.OnJobFinish(ev.result)
+		case eventFrontier:
+			handle.observer.OnFrontier(ev.frontiers)
+		}
+	}
+}
+
+func (h *ObserverHub) emit(ev observerEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, handle := range h.handles {
+		select {
+		case handle.events <- ev:
+		default:
+			log.Printf("workerpool: observer %T queue full, dropping event", handle.observer)
+		}
+	}
+}
+
+// EmitJobStart notifies observers that job has started.
+func (h *ObserverHub) EmitJobStart(job string) {
+	h.emit(observerEvent{kind: eventJobStart, jobName: job})
+}
+
+// EmitJobFinish notifies observers that a job has reached a terminal status.
+func (h *ObserverHub) EmitJobFinish(result *JobResult) {
+	h.emit(observerEvent{kind: eventJobFinish, result: result})
+}
+
+// EmitFrontier notifies observers of the current frontier.
+func (h *ObserverHub) EmitFrontier(f Frontiers) {
+	h.emit(observerEvent{kind: eventFrontier, frontiers: f})
+}
+
+// ProgressObserver prints a live done/total/in-flight/failed count as jobs
+// transition, one line updated in place.
+type ProgressObserver struct {
+	mu       sync.Mutex
+	out      io.Writer
+	total    int
+	done     int
+	failed   int
+	inFlight int
+}
+
+// NewProgressObserver returns a ProgressObserver for a DAG with the given
+// total number of jobs, printing to stdout.
+func NewProgressObserver(total int) *ProgressObserver {
+	return &ProgressObserver{total: total, out: os.Stdout}
+}
+
+func (p *ProgressObserver) OnJobStart(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.inFlight++
+	p.print()
+}
+
+func (p *ProgressObserver) OnJobFinish(result *JobResult) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.inFlight--
+	p.done++
+	if result.IsFailed() {
+		p.failed++
+	}
+	p.print()
+}
+
+func (p *ProgressObserver) OnFrontier(Frontiers) {}
+
+func (p *ProgressObserver) print() {
+	fmt.Fprintf(p.out, "\rdone=%d/%d in-flight=%d failed=%d", p.done, p.total, p.inFlight, p.failed)
+}
+
+// ChannelObserver exposes job completions on a channel for programmatic
+// consumption.
+type ChannelObserver struct {
+	results chan *JobResult
+}
+
+// NewChannelObserver returns a ChannelObserver buffering up to size pending
+// results before OnJobFinish starts dropping them.
+func NewChannelObserver(size int) *ChannelObserver {
+	return &ChannelObserver{results: make(chan *JobResult, size)}
+}
+
+func (c *ChannelObserver) OnJobStart(string) {}
+
+func (c *ChannelObserver) OnJobFinish(result *JobResult) {
+	select {
+	case c.results <- result:
+	default:
+		log.Printf("workerpool: ChannelObserver buffer full, dropping result for %q", result.Name)
+	}
+}
+
+func (c *ChannelObserver) OnFrontier(Frontiers) {}
+
+// Results returns the channel of finished JobResults.
+func (c *ChannelObserver) Results() <-chan *JobResult {
+	return c.results
+}
+
+// Close closes the Results channel, so a `range obs.Results()` consumer
+// unblocks once ObserverHub.Close has stopped dispatching to this observer.
+func (c *ChannelObserver) Close() {
+	close(c.results)
+}
@@ -0,0 +1,140 @@
+package workerpool
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockingObserver blocks in OnJobFinish until release is closed, so tests
+// can force the hub's per-observer queue to fill up.
+type blockingObserver struct {
+	mu       sync.Mutex
+	release  chan struct{}
+	finishes []*JobResult
+}
+
+func (o *blockingObserver) OnJobStart(string) {}
+
+func (o *blockingObserver) OnJobFinish(result *JobResult) {
+	<-o.release
+	o.mu.Lock()
+	o.finishes = append(o.finishes, result)
+	o.mu.Unlock()
+}
+
+func (o *blockingObserver) OnFrontier(Frontiers) {}
+
+func (o *blockingObserver) seen() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.finishes)
+}
+
+func TestObserverHubFansOutToAllObservers(t *testing.T) {
+	hub := NewObserverHub()
+	a := &blockingObserver{release: make(chan struct{})}
+	b := &blockingObserver{release: make(chan struct{})}
+	close(a.release)
+	close(b.release)
+	hub.Register(a)
+	hub.Register(b)
+
+	result := &JobResult{Name: "job", Status: JobStatusSuccess}
+	hub.EmitJobFinish(result)
+	hub.Close()
+
+	if got := a.seen(); got != 1 {
+		t.Errorf("observer a saw %d finishes, want 1", got)
+	}
+	if got := b.seen(); got != 1 {
+		t.Errorf("observer b saw %d finishes, want 1", got)
+	}
+}
+
+func TestObserverHubDropsEventsWhenObserverQueueIsFull(t *testing.T) {
+	hub := NewObserverHub()
+	slow := &blockingObserver{release: make(chan struct{})}
+	hub.Register(slow)
+
+	// Fill the observer's queue (observerQueueSize) plus one in-flight event
+	// consumed by the blocked dispatch goroutine, then send one more: it must
+	// be dropped rather than block EmitJobFinish.
+	for i := 0; i < observerQueueSize+2; i++ {
+		hub.EmitJobFinish(&JobResult{Name: "job"})
+	}
+
+	close(slow.release)
+	hub.Close()
+
+	if got := slow.seen(); got > observerQueueSize+1 {
+		t.Errorf("slow observer processed %d events, want at most %d (queue size + 1 in flight) -- overflow should drop, not block", got, observerQueueSize+1)
+	}
+}
+
+func TestObserverHubCloseStopsDispatchGoroutines(t *testing.T) {
+	hub := NewObserverHub()
+	obs := &blockingObserver{release: make(chan struct{})}
+	close(obs.release)
+	hub.Register(obs)
+
+	done := make(chan struct{})
+	go func() {
+		hub.Close()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("ObserverHub.Close did not return; dispatch goroutine likely still running")
+	}
+}
+
+func TestChannelObserverClosedByHubClose(t *testing.T) {
+	hub := NewObserverHub()
+	obs := NewChannelObserver(1)
+	hub.Register(obs)
+
+	hub.EmitJobFinish(&JobResult{Name: "job", Status: JobStatusSuccess})
+	hub.Close()
+
+	select {
+	case r, ok := <-obs.Results():
+		if !ok {
+			t.Fatal("expected the buffered result before the channel closes")
+		}
+		if r.Name != "job" {
+			t.Errorf("result.Name = %q, want job", r.Name)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out reading buffered result")
+	}
+
+	select {
+	case _, ok := <-obs.Results():
+		if ok {
+			t.Fatal("expected Results() to be closed after ObserverHub.Close")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Results() did not unblock after ObserverHub.Close; range consumers would hang forever")
+	}
+}
+
+func TestProgressObserverTracksCounts(t *testing.T) {
+	p := NewProgressObserver(2)
+	p.OnJobStart("a")
+	p.OnJobFinish(&JobResult{Name: "a", Status: JobStatusSuccess})
+	p.OnJobStart("b")
+	p.OnJobFinish(&JobResult{Name: "b", Status: JobStatusFailed})
+
+	if p.done != 2 {
+		t.Errorf("done = %d, want 2", p.done)
+	}
+	if p.failed != 1 {
+		t.Errorf("failed = %d, want 1", p.failed)
+	}
+	if p.inFlight != 0 {
+		t.Errorf("inFlight = %d, want 0", p.inFlight)
+	}
+}
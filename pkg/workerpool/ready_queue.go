@@ -0,0 +1,33 @@
+package workerpool
+
+import "time"
+
+// ReadyQueueItem is a DAG-ready job awaiting a worker, ranked by its
+// critical-path length.
+type ReadyQueueItem struct {
+	Job          string
+	CriticalPath time.Duration
+}
+
+// ReadyQueue is a container/heap.Interface priority queue of ready jobs,
+// ordered by descending CriticalPath so the scheduler starts whichever job's
+// subtree has the most remaining work first, minimizing overall makespan.
+type ReadyQueue []ReadyQueueItem
+
+func (q ReadyQueue) Len() int { return len(q) }
+
+func (q ReadyQueue) Less(i, j int) bool { return q[i].CriticalPath > q[j].CriticalPath }
+
+func (q ReadyQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *ReadyQueue) Push(x interface{}) {
+	*q = append(*q, x.(ReadyQueueItem))
+}
+
+func (q *ReadyQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	*q = old[:n-1]
+	return item
+}
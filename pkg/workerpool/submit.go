@@ -0,0 +1,111 @@
+package workerpool
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrAlreadyQueued is returned by ResubmitRegistry.Submit when a re-run for
+// the requested job is already pending drain.
+var ErrAlreadyQueued = errors.New("workerpool: resubmission already queued")
+
+// ResubmitRegistry tracks jobs that have been asked to re-run while their DAG
+// is executing, deduping repeated requests for the same job the way Gitea's
+// jobEmitterQueue does. It turns the orchestrator from a one-shot executor
+// into a long-lived reactive engine: call Submit when upstream data changes,
+// and Drain on each scheduler tick to fold the request back into the
+// frontier.
+type ResubmitRegistry struct {
+	mu      sync.Mutex
+	pending map[string]bool
+}
+
+// NewResubmitRegistry returns an empty registry, ready to use.
+func NewResubmitRegistry() *ResubmitRegistry {
+	return &ResubmitRegistry{pending: make(map[string]bool)}
+}
+
+// Submit requests that job (and its cascade-skipped/failed subtree) be
+// re-run. It returns ErrAlreadyQueued if a re-run for job is already pending.
+func (q *ResubmitRegistry) Submit(job string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.pending[job] {
+		return ErrAlreadyQueued
+	}
+	q.pending[job] = true
+	return nil
+}
+
+// Drain pops every pending resubmission and resets the targeted job, plus
+// every successor in its subtree that was JobStatusSkipped or JobStatusFailed
+// as a consequence of it (traced through JobResult.CausedBy, see
+// causedByChainTraces), back to JobStatusUnknown in
+// result. A successor that failed on its own merits rather than as a
+// consequence of job is left untouched. The normal frontier logic then picks
+// the reset jobs back up on the scheduler's next tick. Call this once per
+// scheduler tick.
+func (q *ResubmitRegistry) Drain(dag DAG, result DAGResult) {
+	q.mu.Lock()
+	jobs := make([]string, 0, len(q.pending))
+	for job := range q.pending {
+		jobs = append(jobs, job)
+	}
+	q.pending = make(map[string]bool)
+	q.mu.Unlock()
+
+	rev := result.reverseDAG(dag)
+	for _, job := range jobs {
+		subtree := result.Subtree(rev, job)
+
+		// Decide which targets qualify before resetting any of them: Drain
+		// clears CausedBy as it resets a job, and later targets in the same
+		// subtree may need that still-intact data to trace their own chain
+		// back to job.
+		var toReset []string
+		for _, target := range subtree {
+			if target == job {
+				toReset = append(toReset, target)
+				continue
+			}
+			switch result.get(target).Status {
+			case JobStatusSkipped, JobStatusFailed:
+				if result.causedByChainTraces(target, job) {
+					toReset = append(toReset, target)
+				}
+			}
+		}
+
+		for _, target := range toReset {
+			jr := result.get(target)
+			jr.Status = JobStatusUnknown
+			jr.Error = nil
+			jr.CausedBy = nil
+		}
+	}
+}
+
+// causedByChainTraces reports whether job's CausedBy chain traces back to
+// root. Unlike diagnosis.go's attributableTo (which falls back to true when
+// CausedBy is empty, to stay conservative for display purposes on older
+// checkpoints), this is strict: a job with no recorded CausedBy failed or was
+// skipped on its own, not as a consequence of root, and Drain must leave it
+// alone.
+func (r DAGResult) causedByChainTraces(job, root string) bool {
+	visited := map[string]bool{}
+
+	var trace func(string) bool
+	trace = func(job string) bool {
+		if visited[job] {
+			return false
+		}
+		visited[job] = true
+		for _, c := range r.get(job).CausedBy {
+			if c == root || trace(c) {
+				return true
+			}
+		}
+		return false
+	}
+	return trace(job)
+}
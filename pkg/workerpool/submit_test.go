@@ -0,0 +1,98 @@
+package workerpool
+
+import "testing"
+
+func TestResubmitRegistrySubmitDedupes(t *testing.T) {
+	q := NewResubmitRegistry()
+
+	if err := q.Submit("a"); err != nil {
+		t.Fatalf("first Submit(a): %v", err)
+	}
+	if err := q.Submit("a"); err != ErrAlreadyQueued {
+		t.Fatalf("second Submit(a) = %v, want ErrAlreadyQueued", err)
+	}
+	if err := q.Submit("b"); err != nil {
+		t.Fatalf("Submit(b): %v", err)
+	}
+}
+
+func TestResubmitRegistrySubmitAllowsReSubmitAfterDrain(t *testing.T) {
+	q := NewResubmitRegistry()
+	dag := DAG{"a": nil}
+	result := newDAGResult(dag)
+
+	if err := q.Submit("a"); err != nil {
+		t.Fatalf("Submit(a): %v", err)
+	}
+	q.Drain(dag, result)
+
+	if err := q.Submit("a"); err != nil {
+		t.Fatalf("Submit(a) after Drain = %v, want nil (dedupe must clear once drained)", err)
+	}
+}
+
+func TestResubmitRegistryDrainResetsConsequenceSubtreeOnly(t *testing.T) {
+	// a -> b -> c
+	// a -> d (independent, already successful)
+	// e (unrelated, failed on its own merits)
+	dag := DAG{
+		"a": nil,
+		"b": {"a"},
+		"c": {"b"},
+		"d": {"a"},
+		"e": nil,
+	}
+	result := DAGResult{
+		"a": {Name: "a", Status: JobStatusFailed},
+		"b": {Name: "b", Status: JobStatusSkipped, CausedBy: []string{"a"}},
+		"c": {Name: "c", Status: JobStatusSkipped, CausedBy: []string{"b"}},
+		"d": {Name: "d", Status: JobStatusSuccess},
+		"e": {Name: "e", Status: JobStatusFailed},
+	}
+
+	q := NewResubmitRegistry()
+	if err := q.Submit("a"); err != nil {
+		t.Fatalf("Submit(a): %v", err)
+	}
+	q.Drain(dag, result)
+
+	for _, job := range []string{"a", "b", "c"} {
+		if got := result[job].Status; got != JobStatusUnknown {
+			t.Errorf("result[%s].Status = %v, want Unknown (consequence of resubmitting a)", job, got)
+		}
+	}
+	if got := result["d"].Status; got != JobStatusSuccess {
+		t.Errorf("result[d].Status = %v, want Success (independent successor, must not be reset)", got)
+	}
+	if got := result["e"].Status; got != JobStatusFailed {
+		t.Errorf("result[e].Status = %v, want Failed (unrelated job, failed on its own merits)", got)
+	}
+}
+
+func TestResubmitRegistryDrainLeavesUnrelatedFailureInSubtree(t *testing.T) {
+	// a -> c, b -> c: c failed on its own merits (no CausedBy), not as a
+	// consequence of a.
+	dag := DAG{
+		"a": nil,
+		"b": nil,
+		"c": {"a", "b"},
+	}
+	result := DAGResult{
+		"a": {Name: "a", Status: JobStatusSuccess},
+		"b": {Name: "b", Status: JobStatusSuccess},
+		"c": {Name: "c", Status: JobStatusFailed},
+	}
+
+	q := NewResubmitRegistry()
+	if err := q.Submit("a"); err != nil {
+		t.Fatalf("Submit(a): %v", err)
+	}
+	q.Drain(dag, result)
+
+	if got := result["a"].Status; got != JobStatusUnknown {
+		t.Errorf("result[a].Status = %v, want Unknown (the resubmitted root itself)", got)
+	}
+	if got := result["c"].Status; got != JobStatusFailed {
+		t.Errorf("result[c].Status = %v, want Failed (failed on its own merits, not a consequence of a)", got)
+	}
+}